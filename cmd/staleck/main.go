@@ -0,0 +1,137 @@
+// Command staleck scans a markdown list (such as awesome-go's README) for
+// repository links that look abandoned: archived, moved, deleted, or
+// quiet for a long time. See TestStaleRepository for the CI entry point
+// that wraps this same logic and files a tracking issue.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/avelino/awesome-go/pkg/staleck"
+	"github.com/google/go-github/v55/github"
+	"golang.org/x/oauth2"
+)
+
+func main() {
+	var (
+		limit      = flag.Int("limit", 10, "max number of repositories to flag (or remove, with -auto-pr) in one run (-1 for unlimited)")
+		since      = flag.Duration("since", 365*24*time.Hour, "flag repositories with no commits in this long")
+		workers    = flag.Int("workers", 10, "size of the worker pool used to check repositories")
+		dryRun     = flag.Bool("dry-run", false, "only print findings, don't file a GitHub issue or open a PR")
+		output     = flag.String("output", "issue", "output format: issue, json, or sarif")
+		repoFilter = flag.String("repo-filter", "", "only check repository links matching this regexp")
+		readme     = flag.String("readme", "README.md", "path to the markdown file to scan")
+		autoPR     = flag.Bool("auto-pr", false, "open a PR removing confirmed-dead links instead of only filing an issue; needs GITHUB_PR_TOKEN")
+	)
+	flag.Parse()
+
+	var filter *regexp.Regexp
+	if *repoFilter != "" {
+		var err error
+		filter, err = regexp.Compile(*repoFilter)
+		if err != nil {
+			log.Fatalf("invalid -repo-filter: %v", err)
+		}
+	}
+
+	hrefs, err := staleck.ReadmeLinks(*readme)
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", *readme, err)
+	}
+
+	client := &http.Client{}
+	if token := os.Getenv("OAUTH_TOKEN"); token != "" {
+		client = oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+	}
+	ghClient := github.NewClient(client)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	addressed, err := staleck.OpenFindingURLs(ctx, ghClient, now)
+	if err != nil {
+		log.Fatalf("failed to list existing issues: %v", err)
+	}
+
+	var toCheck []string
+	for _, href := range hrefs {
+		if addressed[href] {
+			log.Printf("issue already exists for %s\n", href)
+			continue
+		}
+		toCheck = append(toCheck, href)
+	}
+
+	findings, err := staleck.Check(ctx, toCheck, staleck.Options{
+		Client:     client,
+		Since:      now.Add(-*since),
+		Workers:    *workers,
+		Limit:      *limit,
+		RepoFilter: filter,
+	})
+	if err != nil {
+		log.Fatalf("staleck: %v", err)
+	}
+
+	switch *output {
+	case "json":
+		if err := json.NewEncoder(os.Stdout).Encode(findings); err != nil {
+			log.Fatalf("failed to encode findings: %v", err)
+		}
+	case "sarif":
+		if err := staleck.WriteSARIF(os.Stdout, findings); err != nil {
+			log.Fatalf("failed to encode findings: %v", err)
+		}
+	case "issue":
+		body, err := staleck.IssueBody(findings)
+		if err != nil {
+			log.Fatalf("failed to render issue body: %v", err)
+		}
+		fmt.Print(body)
+	default:
+		log.Fatalf("unknown -output %q, want issue, json, or sarif", *output)
+	}
+
+	if *dryRun {
+		return
+	}
+
+	if *autoPR {
+		prToken := os.Getenv("GITHUB_PR_TOKEN")
+		if prToken == "" {
+			log.Fatal("-auto-pr requires GITHUB_PR_TOKEN")
+		}
+		prClient := github.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: prToken})))
+
+		var autoRemovable []staleck.Finding
+		var rest []staleck.Finding
+		for _, f := range findings {
+			if f.AutoRemovable() {
+				autoRemovable = append(autoRemovable, f)
+			} else {
+				rest = append(rest, f)
+			}
+		}
+
+		if err := staleck.OpenAutoPR(ctx, prClient, autoRemovable, staleck.AutoPROptions{
+			ReadmePath: *readme,
+			Limit:      *limit,
+			Now:        now,
+		}); err != nil {
+			log.Fatalf("failed to open auto-PR: %v", err)
+		}
+		findings = rest
+	}
+
+	if err := staleck.FileIssue(ctx, ghClient, findings, now); err != nil {
+		log.Fatalf("failed to file issue: %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+package staleck
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// AutoRemovable reports whether a Finding is confident enough to delete
+// the README bullet outright, instead of going through the issue path for
+// human review: the repo API returned 404, the repo is archived, or it's
+// been permanently moved (301) rather than just temporarily redirected.
+// ReasonDeadLink is deliberately excluded: it's also the catch-all for
+// transient failures (rate limits, 5xx that outlived retries), so it's
+// not unambiguous enough to auto-delete on.
+func (f Finding) AutoRemovable() bool {
+	switch f.Reason {
+	case ReasonNotFound, ReasonArchived:
+		return true
+	case ReasonMoved:
+		return f.StatusCode == http.StatusMovedPermanently
+	default:
+		return false
+	}
+}
+
+// RemoveLinks deletes every markdown line in src that links to one of
+// urls, preserving everything else (headings, other bullets, blank
+// lines) untouched. It returns the rewritten content and the lines that
+// were actually removed.
+func RemoveLinks(src []byte, urls map[string]bool) (out []byte, removed []string) {
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var buf bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		isRemoved := false
+		for url := range urls {
+			if strings.Contains(line, "]("+url+")") {
+				isRemoved = true
+				break
+			}
+		}
+		if isRemoved {
+			removed = append(removed, line)
+			continue
+		}
+
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), removed
+}
+
+// AutoPROptions configures OpenAutoPR.
+type AutoPROptions struct {
+	// ReadmePath is the local markdown file to mutate.
+	ReadmePath string
+	// Limit caps the number of bullets removed in a single PR; -1 means
+	// unlimited.
+	Limit int
+	// Now stamps the cleanup branch name and PR title.
+	Now time.Time
+}
+
+// ensureFork forks Owner/Repo for the authenticated user (a no-op if the
+// fork already exists) and waits for GitHub to finish setting it up, so
+// the caller can immediately branch off it.
+func ensureFork(ctx context.Context, client *github.Client, forkOwner, base string) error {
+	_, _, err := client.Repositories.CreateFork(ctx, Owner, Repo, nil)
+	if err != nil {
+		if _, ok := err.(*github.AcceptedError); !ok {
+			return fmt.Errorf("failed to fork %s/%s: %w", Owner, Repo, err)
+		}
+	}
+
+	backoff := time.Second
+	for attempt := 0; attempt < 10; attempt++ {
+		if _, _, err := client.Git.GetRef(ctx, forkOwner, Repo, "refs/heads/"+base); err == nil {
+			return nil
+		}
+		sleepFor(ctx, backoff)
+		backoff *= 2
+	}
+	return fmt.Errorf("fork %s/%s never became ready", forkOwner, Repo)
+}
+
+// OpenAutoPR removes the README bullets for every auto-removable finding
+// (capped at opts.Limit), pushes the mutated file to a
+// staleck/cleanup-YYYY-MM-DD branch on a fork of Owner/Repo owned by the
+// authenticated user, and opens a PR from that branch against Owner/Repo.
+// Forking first means a contributor's GITHUB_PR_TOKEN never needs push
+// access to the upstream repo. Callers should route any findings left out
+// of this pass (low-confidence reasons, or ones past the cap) through
+// FileIssue instead.
+func OpenAutoPR(ctx context.Context, client *github.Client, findings []Finding, opts AutoPROptions) error {
+	urls := make(map[string]bool)
+	for _, f := range findings {
+		if opts.Limit != -1 && len(urls) >= opts.Limit {
+			break
+		}
+		urls[f.URL] = true
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+
+	src, err := os.ReadFile(opts.ReadmePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", opts.ReadmePath, err)
+	}
+	out, removed := RemoveLinks(src, urls)
+	if len(removed) == 0 {
+		return nil
+	}
+
+	var upstream *github.Repository
+	if err := githubDo(ctx, func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		upstream, resp, err = client.Repositories.Get(ctx, Owner, Repo)
+		return resp, err
+	}); err != nil {
+		return fmt.Errorf("failed to look up default branch: %w", err)
+	}
+	base := upstream.GetDefaultBranch()
+
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to look up the authenticated user to fork as: %w", err)
+	}
+	forkOwner := user.GetLogin()
+
+	if err := ensureFork(ctx, client, forkOwner, base); err != nil {
+		return err
+	}
+
+	var baseRef *github.Reference
+	if err := githubDo(ctx, func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		baseRef, resp, err = client.Git.GetRef(ctx, forkOwner, Repo, "refs/heads/"+base)
+		return resp, err
+	}); err != nil {
+		return fmt.Errorf("failed to look up base ref on fork: %w", err)
+	}
+
+	branch := fmt.Sprintf("staleck/cleanup-%s", opts.Now.Format("2006-01-02"))
+	if err := githubDo(ctx, func() (*github.Response, error) {
+		_, resp, err := client.Git.CreateRef(ctx, forkOwner, Repo, &github.Reference{
+			Ref:    github.String("refs/heads/" + branch),
+			Object: baseRef.Object,
+		})
+		return resp, err
+	}); err != nil {
+		return fmt.Errorf("failed to create branch %s on fork: %w", branch, err)
+	}
+
+	// The update below has to name the SHA of the file it replaces on the
+	// new branch, which was cut from the fork's base branch; a fork that
+	// already existed may differ from upstream, so the SHA is read there.
+	var existing *github.RepositoryContent
+	if err := githubDo(ctx, func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		existing, _, resp, err = client.Repositories.GetContents(ctx, forkOwner, Repo, opts.ReadmePath, &github.RepositoryContentGetOptions{Ref: branch})
+		return resp, err
+	}); err != nil {
+		return fmt.Errorf("failed to read %s on %s/%s@%s: %w", opts.ReadmePath, forkOwner, Repo, branch, err)
+	}
+
+	commitMsg := fmt.Sprintf("Remove %d dead link(s) found by staleck", len(removed))
+	if err := githubDo(ctx, func() (*github.Response, error) {
+		_, resp, err := client.Repositories.UpdateFile(ctx, forkOwner, Repo, opts.ReadmePath, &github.RepositoryContentFileOptions{
+			Message: github.String(commitMsg),
+			Content: out,
+			SHA:     existing.SHA,
+			Branch:  github.String(branch),
+		})
+		return resp, err
+	}); err != nil {
+		return fmt.Errorf("failed to push %s to %s/%s@%s: %w", opts.ReadmePath, forkOwner, Repo, branch, err)
+	}
+
+	var body strings.Builder
+	body.WriteString("Removed the following links, flagged stale by `staleck`:\n\n")
+	for _, line := range removed {
+		body.WriteString("- `")
+		body.WriteString(strings.TrimSpace(line))
+		body.WriteString("`\n")
+	}
+
+	head := forkOwner + ":" + branch
+	prTitle := fmt.Sprintf("Remove %d dead link(s) - %s", len(removed), opts.Now.Format("2006-01-02"))
+	if err := githubDo(ctx, func() (*github.Response, error) {
+		_, resp, err := client.PullRequests.Create(ctx, Owner, Repo, &github.NewPullRequest{
+			Title: github.String(prTitle),
+			Head:  github.String(head),
+			Base:  github.String(base),
+			Body:  github.String(body.String()),
+		})
+		return resp, err
+	}); err != nil {
+		return fmt.Errorf("failed to open PR from %s: %w", head, err)
+	}
+	return nil
+}
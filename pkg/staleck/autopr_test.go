@@ -0,0 +1,74 @@
+package staleck
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRemoveLinks(t *testing.T) {
+	src := []byte(`# Awesome Go
+
+## Section
+
+- [foo](https://github.com/foo/bar) - a foo library.
+- [baz](https://github.com/foo/baz) - a baz library.
+- [qux](https://github.com/foo/qux) - a qux library.
+
+## Other section
+`)
+
+	tests := []struct {
+		name        string
+		urls        map[string]bool
+		wantOut     string
+		wantRemoved []string
+	}{
+		{
+			name:    "no urls match",
+			urls:    map[string]bool{"https://github.com/nope/nope": true},
+			wantOut: string(src),
+		},
+		{
+			name: "removes matched lines, keeps the rest",
+			urls: map[string]bool{
+				"https://github.com/foo/bar": true,
+				"https://github.com/foo/qux": true,
+			},
+			wantOut: `# Awesome Go
+
+## Section
+
+- [baz](https://github.com/foo/baz) - a baz library.
+
+## Other section
+`,
+			wantRemoved: []string{
+				"- [foo](https://github.com/foo/bar) - a foo library.",
+				"- [qux](https://github.com/foo/qux) - a qux library.",
+			},
+		},
+		{
+			name:        "no urls given removes nothing",
+			urls:        map[string]bool{},
+			wantOut:     string(src),
+			wantRemoved: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, removed := RemoveLinks(src, tt.urls)
+			if !bytes.Equal(out, []byte(tt.wantOut)) {
+				t.Errorf("RemoveLinks() out = %q, want %q", out, tt.wantOut)
+			}
+			if len(removed) != len(tt.wantRemoved) {
+				t.Fatalf("RemoveLinks() removed = %q, want %q", removed, tt.wantRemoved)
+			}
+			for i, line := range removed {
+				if line != tt.wantRemoved[i] {
+					t.Errorf("RemoveLinks() removed[%d] = %q, want %q", i, line, tt.wantRemoved[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package staleck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var bitbucketGETREPO = "https://api.bitbucket.org/2.0/repositories/%s/%s"
+var bitbucketGETCOMMITS = "https://api.bitbucket.org/2.0/repositories/%s/%s/commits"
+
+type bitbucketForge struct {
+	client *http.Client
+}
+
+func newBitbucketForge(_ string, client *http.Client) ForgeClient {
+	return &bitbucketForge{client: noRedirectClient(client)}
+}
+
+func (b *bitbucketForge) GetRepo(ctx context.Context, owner, repo string) (RepoMeta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(bitbucketGETREPO, owner, repo), nil)
+	if err != nil {
+		return RepoMeta{}, err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return RepoMeta{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return RepoMeta{}, &HTTPStatusError{StatusCode: resp.StatusCode}
+	}
+
+	// Bitbucket Cloud has no "archived" concept for repositories, so this
+	// is always false; staleness there can only be detected via commit age.
+	return RepoMeta{Archived: false}, nil
+}
+
+type bitbucketCommitPage struct {
+	Values []struct {
+		Date time.Time `json:"date"`
+	} `json:"values"`
+}
+
+func (b *bitbucketForge) ListCommitsSince(ctx context.Context, owner, repo string, since time.Time) ([]CommitMeta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(bitbucketGETCOMMITS, owner, repo), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, &HTTPStatusError{StatusCode: resp.StatusCode}
+	}
+
+	// The commits endpoint has no "since" filter and returns newest-first,
+	// so stop as soon as we walk past the cutoff instead of paginating
+	// through the whole history.
+	var page bitbucketCommitPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, err
+	}
+
+	var out []CommitMeta
+	for _, c := range page.Values {
+		if c.Date.Before(since) {
+			break
+		}
+		out = append(out, CommitMeta{AuthoredDate: c.Date})
+	}
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package staleck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// giteaForge talks to Gitea/Forgejo's REST v1 API. It's also used for
+// Codeberg, which runs Forgejo and exposes the same API surface.
+type giteaForge struct {
+	host   string
+	client *http.Client
+}
+
+func newGiteaForge(host string, client *http.Client) ForgeClient {
+	return &giteaForge{host: host, client: noRedirectClient(client)}
+}
+
+func (g *giteaForge) repoURL(owner, repo string) string {
+	return fmt.Sprintf("https://%s/api/v1/repos/%s/%s", g.host, owner, repo)
+}
+
+type giteaRepoResponse struct {
+	Archived bool `json:"archived"`
+}
+
+func (g *giteaForge) GetRepo(ctx context.Context, owner, repo string) (RepoMeta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.repoURL(owner, repo), nil)
+	if err != nil {
+		return RepoMeta{}, err
+	}
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return RepoMeta{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return RepoMeta{}, &HTTPStatusError{StatusCode: resp.StatusCode}
+	}
+
+	var body giteaRepoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return RepoMeta{}, err
+	}
+	return RepoMeta{Archived: body.Archived}, nil
+}
+
+type giteaCommit struct {
+	Commit struct {
+		Author struct {
+			Date time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+func (g *giteaForge) ListCommitsSince(ctx context.Context, owner, repo string, since time.Time) ([]CommitMeta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.repoURL(owner, repo)+"/commits", nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Add("since", since.Format(time.RFC3339))
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, &HTTPStatusError{StatusCode: resp.StatusCode}
+	}
+
+	var commits []giteaCommit
+	if err := json.NewDecoder(resp.Body).Decode(&commits); err != nil {
+		return nil, err
+	}
+	out := make([]CommitMeta, 0, len(commits))
+	for _, c := range commits {
+		out = append(out, CommitMeta{AuthoredDate: c.Commit.Author.Date})
+	}
+	return out, nil
+}
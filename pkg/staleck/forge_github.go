@@ -0,0 +1,136 @@
+package staleck
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// rateLimitThreshold is the minimum number of remaining GitHub API calls
+// before we proactively sleep until the rate limit window resets.
+// Override via GITHUB_RATE_LIMIT_THRESHOLD.
+var rateLimitThreshold = 50
+
+func init() {
+	if v := os.Getenv("GITHUB_RATE_LIMIT_THRESHOLD"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			rateLimitThreshold = n
+		}
+	}
+}
+
+// maxGitHubRetries caps the number of times githubDo retries after a 5xx
+// response before giving up; every earlier attempt counts toward the cap.
+const maxGitHubRetries = 5
+
+// githubDo runs fn, honoring GitHub's rate-limit headers and retrying
+// with exponential backoff on 5xx responses and secondary (abuse) rate
+// limits. fn should perform exactly one API call and return its
+// *github.Response alongside any error.
+func githubDo(ctx context.Context, fn func() (*github.Response, error)) error {
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		resp, err := fn()
+		if resp != nil && resp.Rate.Remaining <= rateLimitThreshold && !resp.Rate.Reset.IsZero() {
+			sleepUntil(ctx, resp.Rate.Reset.Time)
+		}
+		if err == nil {
+			return nil
+		}
+
+		var rateErr *github.RateLimitError
+		if errors.As(err, &rateErr) {
+			sleepUntil(ctx, rateErr.Rate.Reset.Time)
+			continue
+		}
+
+		var abuseErr *github.AbuseRateLimitError
+		if errors.As(err, &abuseErr) {
+			wait := backoff
+			if abuseErr.RetryAfter != nil {
+				wait = *abuseErr.RetryAfter
+			}
+			sleepFor(ctx, wait)
+			backoff *= 2
+			continue
+		}
+
+		if resp != nil && resp.StatusCode >= http.StatusInternalServerError && attempt < maxGitHubRetries {
+			sleepFor(ctx, backoff)
+			backoff *= 2
+			continue
+		}
+
+		return err
+	}
+}
+
+func sleepUntil(ctx context.Context, t time.Time) {
+	sleepFor(ctx, time.Until(t))
+}
+
+func sleepFor(ctx context.Context, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	log.Printf("GitHub: backing off for %s", d)
+	select {
+	case <-time.After(d):
+	case <-ctx.Done():
+	}
+}
+
+type githubForge struct {
+	client *github.Client
+}
+
+func newGitHubForge(_ string, client *http.Client) ForgeClient {
+	return &githubForge{client: github.NewClient(noRedirectClient(client))}
+}
+
+func (g *githubForge) GetRepo(ctx context.Context, owner, repo string) (RepoMeta, error) {
+	var ghRepo *github.Repository
+	err := githubDo(ctx, func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		ghRepo, resp, err = g.client.Repositories.Get(ctx, owner, repo)
+		return resp, err
+	})
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil {
+			return RepoMeta{}, &HTTPStatusError{StatusCode: errResp.Response.StatusCode}
+		}
+		return RepoMeta{}, err
+	}
+	return RepoMeta{Archived: ghRepo.GetArchived()}, nil
+}
+
+func (g *githubForge) ListCommitsSince(ctx context.Context, owner, repo string, since time.Time) ([]CommitMeta, error) {
+	var commits []*github.RepositoryCommit
+	err := githubDo(ctx, func() (*github.Response, error) {
+		var resp *github.Response
+		var err error
+		commits, resp, err = g.client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{Since: since})
+		return resp, err
+	})
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil {
+			return nil, &HTTPStatusError{StatusCode: errResp.Response.StatusCode}
+		}
+		return nil, err
+	}
+
+	out := make([]CommitMeta, 0, len(commits))
+	for _, c := range commits {
+		out = append(out, CommitMeta{AuthoredDate: c.GetCommit().GetAuthor().GetDate().Time})
+	}
+	return out, nil
+}
@@ -0,0 +1,64 @@
+package staleck
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// gitlabForge talks to gitlab.com via go-gitlab, the same library
+// go-gitlab's own maintainers recommend over hand-rolling the REST calls
+// (it already knows how to paginate, encode project paths, and translate
+// error responses).
+type gitlabForge struct {
+	client *gitlab.Client
+	// err is set if the client itself failed to construct, which can only
+	// happen if a bad base URL were configured - it never is here, since
+	// newGitLabForge always talks to the default gitlab.com endpoint.
+	err error
+}
+
+func newGitLabForge(_ string, client *http.Client) ForgeClient {
+	gl, err := gitlab.NewClient("", gitlab.WithHTTPClient(noRedirectClient(client)))
+	return &gitlabForge{client: gl, err: err}
+}
+
+func (g *gitlabForge) GetRepo(ctx context.Context, owner, repo string) (RepoMeta, error) {
+	if g.err != nil {
+		return RepoMeta{}, g.err
+	}
+	proj, resp, err := g.client.Projects.GetProject(owner+"/"+repo, nil, gitlab.WithContext(ctx))
+	if err != nil {
+		if resp != nil && resp.StatusCode >= http.StatusMultipleChoices {
+			return RepoMeta{}, &HTTPStatusError{StatusCode: resp.StatusCode}
+		}
+		return RepoMeta{}, err
+	}
+	return RepoMeta{Archived: proj.Archived}, nil
+}
+
+func (g *gitlabForge) ListCommitsSince(ctx context.Context, owner, repo string, since time.Time) ([]CommitMeta, error) {
+	if g.err != nil {
+		return nil, g.err
+	}
+	commits, resp, err := g.client.Commits.ListCommits(owner+"/"+repo, &gitlab.ListCommitsOptions{
+		Since: &since,
+	}, gitlab.WithContext(ctx))
+	if err != nil {
+		if resp != nil && resp.StatusCode >= http.StatusMultipleChoices {
+			return nil, &HTTPStatusError{StatusCode: resp.StatusCode}
+		}
+		return nil, err
+	}
+
+	out := make([]CommitMeta, 0, len(commits))
+	for _, c := range commits {
+		if c.AuthoredDate == nil {
+			continue
+		}
+		out = append(out, CommitMeta{AuthoredDate: *c.AuthoredDate})
+	}
+	return out, nil
+}
@@ -0,0 +1,82 @@
+package staleck
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// Owner and Repo identify where stale-repository issues are filed.
+const Owner = "avelino"
+const Repo = "awesome-go"
+
+// IssueTitle is the fixed, date-stamped title used both to file and to
+// recognize the stale-repository tracking issue.
+func IssueTitle(t time.Time) string {
+	return fmt.Sprintf("Investigate repositories with more than 1 year without update - %s", t.Format("2006-01-02"))
+}
+
+// FileIssue posts findings as a checklist issue against Owner/Repo.
+func FileIssue(ctx context.Context, client *github.Client, findings []Finding, now time.Time) error {
+	if len(findings) == 0 {
+		log.Print("NO STALE REPOSITORIES")
+		return nil
+	}
+
+	body, err := IssueBody(findings)
+	if err != nil {
+		return err
+	}
+
+	title := IssueTitle(now)
+	req := &github.IssueRequest{Title: &title, Body: &body}
+	return githubDo(ctx, func() (*github.Response, error) {
+		_, resp, err := client.Issues.Create(ctx, Owner, Repo, req)
+		return resp, err
+	})
+}
+
+// OpenFindingURLs lists the repository URLs already referenced by an open
+// tracking issue, so callers can skip re-flagging them.
+func OpenFindingURLs(ctx context.Context, client *github.Client, now time.Time) (map[string]bool, error) {
+	title := IssueTitle(now)
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	flagged := make(map[string]bool)
+	for {
+		var page []*github.Issue
+		var nextPage int
+		err := githubDo(ctx, func() (*github.Response, error) {
+			var resp *github.Response
+			var err error
+			page, resp, err = client.Issues.ListByRepo(ctx, Owner, Repo, opts)
+			if resp != nil {
+				nextPage = resp.NextPage
+			}
+			return resp, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range page {
+			if i.GetTitle() == title {
+				for _, url := range ParseChecklistURLs(i.GetBody()) {
+					flagged[url] = true
+				}
+			}
+		}
+
+		if nextPage == 0 {
+			break
+		}
+		opts.Page = nextPage
+	}
+	return flagged, nil
+}
@@ -0,0 +1,77 @@
+package staleck
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+const deadLinkMessage = " this repository might no longer exist! (status code >= 400 returned)"
+const movedPermanentlyMessage = " status code 301 received"
+const status302Message = " status code 302 received"
+const archivedMessage = " repository has been archived"
+
+// String renders a Finding the way it has always shown up in the
+// tracking issue: the bare URL plus a human-readable status suffix.
+func (f Finding) String() string {
+	switch f.Reason {
+	case ReasonMoved:
+		if f.StatusCode == 302 {
+			return f.URL + status302Message
+		}
+		return f.URL + movedPermanentlyMessage
+	case ReasonDeadLink, ReasonNotFound:
+		return f.URL + deadLinkMessage
+	case ReasonArchived:
+		return f.URL + archivedMessage
+	default:
+		return f.URL
+	}
+}
+
+const issueTemplateContent = `
+{{range .}}
+- [ ] {{.}}
+{{end}}
+`
+
+var issueTemplate = template.Must(template.New("issue").Parse(issueTemplateContent))
+
+// IssueBody renders findings into the checklist body used for the
+// tracking issue filed against Owner/Repo.
+func IssueBody(findings []Finding) (string, error) {
+	lines := make([]string, 0, len(findings))
+	for _, f := range findings {
+		lines = append(lines, f.String())
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := issueTemplate.Execute(buf, lines); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// StripStatusSuffix removes any status suffix appended by Finding.String,
+// recovering the bare repository URL.
+func StripStatusSuffix(s string) string {
+	for _, suffix := range []string{deadLinkMessage, movedPermanentlyMessage, status302Message, archivedMessage} {
+		s = strings.ReplaceAll(s, suffix, "")
+	}
+	return s
+}
+
+// ParseChecklistURLs recovers the bare repository URLs from an issue body
+// rendered by IssueBody.
+func ParseChecklistURLs(body string) []string {
+	links := strings.Split(body, "- ")
+	for idx, link := range links {
+		str := strings.ReplaceAll(link, "\r", "")
+		str = StripStatusSuffix(str)
+		str = strings.ReplaceAll(str, "[ ]", "")
+		str = strings.ReplaceAll(str, "[x]", "")
+		str = strings.ReplaceAll(str, " ", "")
+		str = strings.ReplaceAll(str, "\n", "")
+		links[idx] = str
+	}
+	return links
+}
@@ -0,0 +1,85 @@
+package staleck
+
+import "testing"
+
+func TestFindingString(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Finding
+		want string
+	}{
+		{
+			name: "moved permanently",
+			f:    Finding{URL: "https://github.com/foo/bar", Reason: ReasonMoved, StatusCode: 301},
+			want: "https://github.com/foo/bar" + movedPermanentlyMessage,
+		},
+		{
+			name: "found (302)",
+			f:    Finding{URL: "https://github.com/foo/bar", Reason: ReasonMoved, StatusCode: 302},
+			want: "https://github.com/foo/bar" + status302Message,
+		},
+		{
+			name: "dead link",
+			f:    Finding{URL: "https://github.com/foo/bar", Reason: ReasonDeadLink},
+			want: "https://github.com/foo/bar" + deadLinkMessage,
+		},
+		{
+			name: "not found",
+			f:    Finding{URL: "https://github.com/foo/bar", Reason: ReasonNotFound},
+			want: "https://github.com/foo/bar" + deadLinkMessage,
+		},
+		{
+			name: "archived",
+			f:    Finding{URL: "https://github.com/foo/bar", Reason: ReasonArchived},
+			want: "https://github.com/foo/bar" + archivedMessage,
+		},
+		{
+			name: "no recent commits has no suffix",
+			f:    Finding{URL: "https://github.com/foo/bar", Reason: ReasonNoRecentCommits},
+			want: "https://github.com/foo/bar",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.f.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseChecklistURLs(t *testing.T) {
+	findings := []Finding{
+		{URL: "https://github.com/foo/bar", Reason: ReasonNotFound},
+		{URL: "https://github.com/foo/baz", Reason: ReasonMoved, StatusCode: 301},
+		{URL: "https://github.com/foo/qux", Reason: ReasonArchived},
+	}
+	want := []string{
+		"https://github.com/foo/bar",
+		"https://github.com/foo/baz",
+		"https://github.com/foo/qux",
+	}
+
+	body, err := IssueBody(findings)
+	if err != nil {
+		t.Fatalf("IssueBody() error = %v", err)
+	}
+
+	got := ParseChecklistURLs(body)
+	var urls []string
+	for _, u := range got {
+		if u != "" {
+			urls = append(urls, u)
+		}
+	}
+
+	if len(urls) != len(want) {
+		t.Fatalf("ParseChecklistURLs() = %q, want %q", urls, want)
+	}
+	for i, u := range urls {
+		if u != want[i] {
+			t.Errorf("ParseChecklistURLs()[%d] = %q, want %q", i, u, want[i])
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package staleck
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/avelino/awesome-go/pkg/markdown"
+)
+
+// ReadmeLinks parses path as the awesome-go README and returns the leading
+// link of every list item, nested lists included, in document order.
+func ReadmeLinks(path string) ([]string, error) {
+	input, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	html, err := markdown.ConvertMarkdownToHTML(input)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(html))
+	if err != nil {
+		return nil, err
+	}
+
+	var hrefs []string
+	doc.Find("body li > a:first-child").Each(func(_ int, s *goquery.Selection) {
+		if href, ok := s.Attr("href"); ok {
+			hrefs = append(hrefs, href)
+		}
+	})
+	return hrefs, nil
+}
@@ -0,0 +1,224 @@
+// Package staleck checks whether the repositories linked from a markdown
+// list (such as awesome-go's README) are still around: not archived, not
+// moved, not deleted, and not gone quiet for a long stretch.
+package staleck
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Reason enumerates why a repository was flagged.
+type Reason string
+
+const (
+	ReasonArchived        Reason = "Archived"
+	ReasonMoved           Reason = "Moved"
+	ReasonDeadLink        Reason = "DeadLink"
+	ReasonNoRecentCommits Reason = "NoRecentCommits"
+	ReasonNotFound        Reason = "NotFound"
+)
+
+// Finding is one repository link flagged as stale.
+type Finding struct {
+	URL        string
+	Reason     Reason
+	LastCommit time.Time
+	StatusCode int
+}
+
+// Options configures a Check run.
+type Options struct {
+	// Client is the HTTP client used to talk to forges, e.g. one carrying
+	// a GitHub OAuth token. Defaults to http.DefaultClient.
+	Client *http.Client
+	// Since is the cutoff before which "no commits" counts as stale.
+	Since time.Time
+	// Workers bounds the worker pool fanning checks out concurrently.
+	// Defaults to 10.
+	Workers int
+	// Limit caps the number of findings returned; -1 means unlimited.
+	Limit int
+	// RepoFilter, if set, restricts checking to links matching this regexp.
+	RepoFilter *regexp.Regexp
+}
+
+// job is one href to check, tagged with its position in the input so the
+// result can be placed back in document order once the worker pool (which
+// finishes hrefs out of order) is done.
+type job struct {
+	index int
+	href  string
+}
+
+type indexedFinding struct {
+	index   int
+	finding Finding
+	ok      bool
+}
+
+// Check runs the stale-repository checks concurrently across urls,
+// honoring ctx cancellation. Results are assembled in document (urls)
+// order: once the longest *contiguous* prefix of checked hrefs has
+// produced opts.Limit findings, the feeder stops submitting new work and
+// in-flight checks are left to drain, the same way the old sequential
+// EachWithBreak loop stopped after its first Limit hits. Because the
+// prefix is contiguous, which findings make the cut never depends on
+// which worker happens to finish first - unlike checking everything and
+// truncating after the fact, this also means a run over a mostly-fresh
+// README stops well short of the end instead of spending the whole rate
+// limit budget on repos that will just be thrown away. The returned
+// findings are sorted by URL for deterministic output.
+func Check(ctx context.Context, urls []string, opts Options) ([]Finding, error) {
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = 10
+	}
+	if opts.RepoFilter != nil {
+		var matched []string
+		for _, href := range urls {
+			if opts.RepoFilter.MatchString(href) {
+				matched = append(matched, href)
+			}
+		}
+		urls = matched
+	}
+
+	feedCtx, stopFeeding := context.WithCancel(ctx)
+	defer stopFeeding()
+
+	jobs := make(chan job)
+	results := make(chan indexedFinding)
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				found, ok := checkOne(ctx, j.href, client, opts.Since)
+				results <- indexedFinding{index: j.index, finding: found, ok: ok}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for i, href := range urls {
+			select {
+			case jobs <- job{index: i, href: href}:
+			case <-feedCtx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// pending holds results that arrived ahead of their turn; next is the
+	// index of the earliest href whose result hasn't been folded into
+	// findings yet. Only one goroutine (this loop) ever touches either, so
+	// no lock is needed to keep them consistent with each other.
+	pending := make(map[int]indexedFinding)
+	next := 0
+	var findings []Finding
+	for r := range results {
+		pending[r.index] = r
+		for {
+			res, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			if res.ok {
+				findings = append(findings, res.finding)
+			}
+		}
+		if opts.Limit != -1 && len(findings) >= opts.Limit {
+			stopFeeding()
+		}
+	}
+
+	if opts.Limit != -1 && len(findings) > opts.Limit {
+		findings = findings[:opts.Limit]
+	}
+	sort.Slice(findings, func(i, j int) bool { return findings[i].URL < findings[j].URL })
+	return findings, ctx.Err()
+}
+
+// checkOne runs the moved/archived/dead-link/commit-age checks for a
+// single href, dispatching to whichever ForgeClient understands its host.
+func checkOne(ctx context.Context, href string, client *http.Client, since time.Time) (Finding, bool) {
+	forge, owner, repo, ok := forgeFor(href, client)
+	if !ok {
+		log.Printf("%s repo forge not currently handled", href)
+		return Finding{}, false
+	}
+
+	meta, err := forge.GetRepo(ctx, owner, repo)
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusMovedPermanently, http.StatusFound:
+			log.Printf("%s returned %d", href, statusErr.StatusCode)
+			return Finding{URL: href, Reason: ReasonMoved, StatusCode: statusErr.StatusCode}, true
+		case http.StatusNotFound:
+			log.Printf("%s might not exist!", href)
+			return Finding{URL: href, Reason: ReasonNotFound, StatusCode: statusErr.StatusCode}, true
+		default:
+			log.Printf("%s might not exist!", href)
+			return Finding{URL: href, Reason: ReasonDeadLink, StatusCode: statusErr.StatusCode}, true
+		}
+	}
+	if err != nil {
+		log.Printf("Failed at repository %s: %v\n", href, err)
+		return Finding{}, false
+	}
+	if meta.Archived {
+		log.Printf("%s is archived!", href)
+		return Finding{URL: href, Reason: ReasonArchived}, true
+	}
+
+	// A single unfiltered fetch (rather than one call filtered on since to
+	// decide staleness, then a second unfiltered one to find the actual
+	// last-commit date) gives us both answers: forges return commits
+	// newest-first, so the most recent entry is both the candidate for
+	// LastCommit and enough to tell whether anything landed after since.
+	commits, err := forge.ListCommitsSince(ctx, owner, repo, time.Time{})
+	if err != nil {
+		log.Printf("Failed at repository %s: %v\n", href, err)
+		return Finding{}, false
+	}
+	latest := latestCommitDate(commits)
+	if latest.Before(since) {
+		log.Printf("%s has not had a commit in a while", href)
+		return Finding{URL: href, Reason: ReasonNoRecentCommits, LastCommit: latest}, true
+	}
+	return Finding{}, false
+}
+
+// latestCommitDate returns the maximum AuthoredDate across commits, or the
+// zero Time if commits is empty.
+func latestCommitDate(commits []CommitMeta) time.Time {
+	var latest time.Time
+	for _, c := range commits {
+		if c.AuthoredDate.After(latest) {
+			latest = c.AuthoredDate
+		}
+	}
+	return latest
+}